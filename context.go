@@ -0,0 +1,53 @@
+package gerrit
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// This file covers a representative slice of the service surface
+// (AccountsService, ChangesService and ProjectsService), not every service
+// method -- the rest of the services (AccessService, ConfigService,
+// GroupsService, PluginsService, EventsLogService) don't have ...Context
+// variants yet. Add them the same way as the need arises.
+
+// GetAccountContext is the context-aware variant of AccountsService.GetAccount.
+func (s *AccountsService) GetAccountContext(ctx context.Context, accountID string) (*AccountInfo, *Response, error) {
+	u := fmt.Sprintf("accounts/%s", accountID)
+	v := new(AccountInfo)
+	resp, err := s.client.CallWithContext(ctx, "GET", u, nil, v)
+	return v, resp, err
+}
+
+// GetChangeContext is the context-aware variant of ChangesService.GetChange.
+func (s *ChangesService) GetChangeContext(ctx context.Context, changeID string, opt *ChangeOptions) (*ChangeInfo, *Response, error) {
+	u, err := addOptions(fmt.Sprintf("changes/%s", changeID), opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	v := new(ChangeInfo)
+	resp, err := s.client.CallWithContext(ctx, "GET", u, nil, v)
+	return v, resp, err
+}
+
+// ListChangesContext is the context-aware variant of ChangesService.ListChanges.
+func (s *ChangesService) ListChangesContext(ctx context.Context, opt *QueryChangeOptions) (*[]ChangeInfo, *Response, error) {
+	u, err := addOptions("changes/", opt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	v := new([]ChangeInfo)
+	resp, err := s.client.CallWithContext(ctx, "GET", u, nil, v)
+	return v, resp, err
+}
+
+// GetProjectContext is the context-aware variant of ProjectsService.GetProject.
+func (s *ProjectsService) GetProjectContext(ctx context.Context, projectName string) (*ProjectInfo, *Response, error) {
+	u := fmt.Sprintf("projects/%s", url.QueryEscape(projectName))
+	v := new(ProjectInfo)
+	resp, err := s.client.CallWithContext(ctx, "GET", u, nil, v)
+	return v, resp, err
+}
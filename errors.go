@@ -0,0 +1,102 @@
+package gerrit
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+var (
+	// ErrNotFound is the sentinel error wrapped by an *ErrorResponse for a
+	// 404 Not Found response. Use errors.Is(err, ErrNotFound) to test for
+	// it.
+	ErrNotFound = errors.New("gerrit: not found")
+
+	// ErrUnauthorized is the sentinel error wrapped by an *ErrorResponse
+	// for a 401 Unauthorized response.
+	ErrUnauthorized = errors.New("gerrit: unauthorized")
+
+	// ErrConflict is the sentinel error wrapped by an *ErrorResponse for a
+	// 409 Conflict response.
+	ErrConflict = errors.New("gerrit: conflict")
+
+	// ErrRateLimited is the sentinel error wrapped by an *ErrorResponse for
+	// a 429 Too Many Requests response.
+	ErrRateLimited = errors.New("gerrit: rate limited")
+)
+
+// ErrorResponse reports a non-2xx response from the Gerrit API. It embeds
+// the *http.Response so callers can inspect headers and status, and
+// captures the raw body: Gerrit returns human-readable plain-text error
+// messages for 4xx/5xx responses, not JSON.
+type ErrorResponse struct {
+	*http.Response
+
+	// RawBody is the raw response body, as returned by Gerrit. Named to
+	// avoid shadowing the embedded *http.Response's Body (an
+	// io.ReadCloser over the same, already-drained, stream).
+	RawBody []byte
+}
+
+func (e *ErrorResponse) Error() string {
+	return fmt.Sprintf("API call to %s failed: %s: %s",
+		e.Request.URL, e.Status, bytes.TrimSpace(e.RawBody))
+}
+
+// IsNotFound reports whether the error response was a 404 Not Found.
+func (e *ErrorResponse) IsNotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
+
+// IsUnauthorized reports whether the error response was a 401 Unauthorized.
+func (e *ErrorResponse) IsUnauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized
+}
+
+// IsConflict reports whether the error response was a 409 Conflict.
+func (e *ErrorResponse) IsConflict() bool {
+	return e.StatusCode == http.StatusConflict
+}
+
+// IsRateLimited reports whether the error response was a 429 Too Many
+// Requests.
+func (e *ErrorResponse) IsRateLimited() bool {
+	return e.StatusCode == http.StatusTooManyRequests
+}
+
+// Unwrap exposes the sentinel error matching the response's status code, if
+// any, so that errors.Is(err, ErrNotFound) and friends work against an
+// *ErrorResponse returned from CheckResponse.
+func (e *ErrorResponse) Unwrap() error {
+	switch e.StatusCode {
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusUnauthorized:
+		return ErrUnauthorized
+	case http.StatusConflict:
+		return ErrConflict
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	default:
+		return nil
+	}
+}
+
+// readErrorBody reads r's body, transparently decoding it if Gerrit sent a
+// gzip-encoded error response.
+func readErrorBody(r *http.Response) []byte {
+	reader := io.Reader(r.Body)
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		if gzReader, err := gzip.NewReader(r.Body); err == nil {
+			defer gzReader.Close()
+			reader = gzReader
+		}
+	}
+
+	data, _ := ioutil.ReadAll(reader)
+	return data
+}
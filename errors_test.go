@@ -0,0 +1,110 @@
+package gerrit
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func newTestErrorResponse(t *testing.T, statusCode int, body []byte, gzipEncode bool) *http.Response {
+	t.Helper()
+
+	payload := body
+	header := http.Header{}
+	if gzipEncode {
+		var buf bytes.Buffer
+		w := gzip.NewWriter(&buf)
+		if _, err := w.Write(body); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		payload = buf.Bytes()
+		header.Set("Content-Encoding", "gzip")
+	}
+
+	u, err := url.Parse("https://review.example.com/a/accounts/self")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &http.Response{
+		StatusCode: statusCode,
+		Status:     http.StatusText(statusCode),
+		Header:     header,
+		Body:       ioutil.NopCloser(bytes.NewReader(payload)),
+		Request:    &http.Request{URL: u},
+	}
+}
+
+func TestReadErrorBody(t *testing.T) {
+	want := []byte("not found\n")
+
+	t.Run("plain", func(t *testing.T) {
+		resp := newTestErrorResponse(t, 404, want, false)
+		if got := readErrorBody(resp); !bytes.Equal(got, want) {
+			t.Errorf("readErrorBody() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("gzip-encoded", func(t *testing.T) {
+		resp := newTestErrorResponse(t, 404, want, true)
+		if got := readErrorBody(resp); !bytes.Equal(got, want) {
+			t.Errorf("readErrorBody() = %q, want %q (should transparently decode gzip)", got, want)
+		}
+	})
+}
+
+func TestCheckResponse(t *testing.T) {
+	if err := CheckResponse(newTestErrorResponse(t, 200, nil, false)); err != nil {
+		t.Errorf("CheckResponse() = %v for a 200, want nil", err)
+	}
+
+	resp := newTestErrorResponse(t, 404, []byte("Not found: foo"), false)
+	err := CheckResponse(resp)
+	if err == nil {
+		t.Fatal("CheckResponse() = nil for a 404, want an error")
+	}
+
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) {
+		t.Fatalf("CheckResponse() error is not an *ErrorResponse: %v", err)
+	}
+	if string(errResp.RawBody) != "Not found: foo" {
+		t.Errorf("RawBody = %q, want %q", errResp.RawBody, "Not found: foo")
+	}
+	if !errResp.IsNotFound() {
+		t.Error("IsNotFound() = false for a 404")
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Error("errors.Is(err, ErrNotFound) = false")
+	}
+}
+
+func TestErrorResponsePredicates(t *testing.T) {
+	tests := []struct {
+		statusCode int
+		want       error
+		check      func(*ErrorResponse) bool
+	}{
+		{http.StatusNotFound, ErrNotFound, (*ErrorResponse).IsNotFound},
+		{http.StatusUnauthorized, ErrUnauthorized, (*ErrorResponse).IsUnauthorized},
+		{http.StatusConflict, ErrConflict, (*ErrorResponse).IsConflict},
+		{http.StatusTooManyRequests, ErrRateLimited, (*ErrorResponse).IsRateLimited},
+	}
+
+	for _, tt := range tests {
+		errResp := &ErrorResponse{Response: newTestErrorResponse(t, tt.statusCode, nil, false)}
+		if !tt.check(errResp) {
+			t.Errorf("predicate for status %d returned false", tt.statusCode)
+		}
+		if !errors.Is(errResp, tt.want) {
+			t.Errorf("errors.Is(errResp, %v) = false for status %d", tt.want, tt.statusCode)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package gerrit
+
+import "testing"
+
+func TestDecodeEvent(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want string // expected EventType()
+	}{
+		{
+			name: "patchset-created",
+			json: `{"type":"patchset-created","change":{"project":"foo"},"uploader":{"name":"alice"}}`,
+			want: "patchset-created",
+		},
+		{
+			name: "comment-added",
+			json: `{"type":"comment-added","comment":"looks good"}`,
+			want: "comment-added",
+		},
+		{
+			name: "change-merged",
+			json: `{"type":"change-merged"}`,
+			want: "change-merged",
+		},
+		{
+			name: "unknown type falls back to baseEvent",
+			json: `{"type":"ref-updated"}`,
+			want: "ref-updated",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := decodeEvent([]byte(tt.json))
+			if err != nil {
+				t.Fatalf("decodeEvent() error = %v", err)
+			}
+			if got := event.EventType(); got != tt.want {
+				t.Errorf("EventType() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeEventPreservesTypedFields(t *testing.T) {
+	event, err := decodeEvent([]byte(`{"type":"comment-added","comment":"looks good"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commentAdded, ok := event.(CommentAddedEvent)
+	if !ok {
+		t.Fatalf("decodeEvent() returned %T, want CommentAddedEvent", event)
+	}
+	if commentAdded.Comment != "looks good" {
+		t.Errorf("Comment = %q, want %q", commentAdded.Comment, "looks good")
+	}
+}
+
+func TestDecodeEventInvalidJSON(t *testing.T) {
+	if _, err := decodeEvent([]byte("not json")); err == nil {
+		t.Error("decodeEvent() error = nil for invalid JSON, want an error")
+	}
+}
@@ -2,6 +2,8 @@ package gerrit
 
 import (
 	"bytes"
+	"compress/gzip"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -28,6 +30,15 @@ type Client struct {
 	// BaseURL should always be specified with a trailing slash.
 	baseURL *url.URL
 
+	// DisableCompression disables Accept-Encoding: gzip negotiation on
+	// outgoing requests. By default the client requests gzip-encoded
+	// responses and transparently decodes them in Do.
+	DisableCompression bool
+
+	// Retry configures how Do retries a request that failed with a
+	// transient error. The zero value disables retrying.
+	Retry RetryPolicy
+
 	// Gerrit service for authentication
 	Authentication *AuthenticationService
 
@@ -92,6 +103,37 @@ func NewClient(endpoint string, httpClient *http.Client) (*Client, error) {
 		return nil, err
 	}
 
+	// "oauth2://<token>@host/path" is shorthand for authenticating with an
+	// OAuth2 bearer token over HTTPS, as used by googlesource.com-hosted
+	// Gerrit instances. Rewrite it to a plain https:// URL and configure
+	// the token, skipping the digest/basic/cookie probing below.
+	if baseURL.Scheme == "oauth2" {
+		if baseURL.User == nil {
+			return nil, ErrUserProvidedWithoutPassword
+		}
+		token := baseURL.User.Username()
+
+		baseURL.Scheme = "https"
+		baseURL.User = nil
+
+		c := &Client{
+			client:  httpClient,
+			baseURL: baseURL,
+		}
+		c.Authentication = &AuthenticationService{client: c}
+		c.Access = &AccessService{client: c}
+		c.Accounts = &AccountsService{client: c}
+		c.Changes = &ChangesService{client: c}
+		c.Config = &ConfigService{client: c}
+		c.Groups = &GroupsService{client: c}
+		c.Plugins = &PluginsService{client: c}
+		c.Projects = &ProjectsService{client: c}
+		c.EventsLog = &EventsLogService{client: c}
+
+		c.Authentication.SetOAuthToken(token)
+		return c, nil
+	}
+
 	// Username and/or password provided as part of the url.
 
 	hasAuth := false
@@ -181,6 +223,14 @@ func checkAuth(client *Client) (bool, error) {
 // Relative URLs should always be specified without a preceding slash.
 // If specified, the value pointed to by body is JSON encoded and included as the request body.
 func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Request, error) {
+	return c.NewRequestWithContext(context.Background(), method, urlStr, body)
+}
+
+// NewRequestWithContext creates an API request bound to ctx, identically to
+// NewRequest. The returned request, along with the digest-auth preflight
+// request used to populate it (if digest auth is configured), is cancelled
+// when ctx is done.
+func (c *Client) NewRequestWithContext(ctx context.Context, method, urlStr string, body interface{}) (*http.Request, error) {
 	// Build URL for request
 	u, err := c.buildURLForRequest(urlStr)
 	if err != nil {
@@ -200,9 +250,10 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Requ
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
 
 	// Apply Authentication
-	if err := c.addAuthentication(req); err != nil {
+	if err := c.addAuthentication(ctx, req); err != nil {
 		return nil, err
 	}
 
@@ -211,9 +262,12 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Requ
 	req.Header.Add("Accept", "application/json")
 	req.Header.Add("Content-Type", "application/json")
 
-	// TODO: Add gzip encoding
-	// Accept-Encoding request header is set to gzip
+	// Accept-Encoding request header is set to gzip, and the gzipped
+	// response is transparently decoded in Do.
 	// See https://gerrit-review.googlesource.com/Documentation/rest-api.html#output
+	if !c.DisableCompression {
+		req.Header.Add("Accept-Encoding", "gzip")
+	}
 
 	return req, nil
 }
@@ -232,7 +286,14 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Requ
 //
 // For more information read https://github.com/google/go-github/issues/234
 func (c *Client) Call(method, u string, body interface{}, v interface{}) (*Response, error) {
-	req, err := c.NewRequest(method, u, body)
+	return c.CallWithContext(context.Background(), method, u, body, v)
+}
+
+// CallWithContext is the context-aware counterpart of Call. ctx is attached
+// to both the outgoing request and, if digest auth is configured, its
+// preflight request, so cancelling ctx aborts the call in either phase.
+func (c *Client) CallWithContext(ctx context.Context, method, u string, body interface{}, v interface{}) (*Response, error) {
+	req, err := c.NewRequestWithContext(ctx, method, u, body)
 	if err != nil {
 		return nil, err
 	}
@@ -266,7 +327,10 @@ func (c *Client) buildURLForRequest(urlStr string) (string, error) {
 
 	// If we are authenticated, lets apply the a/ prefix but only if it has
 	// not already been applied.
-	if c.Authentication.HasAuth() == true && !strings.HasPrefix(urlStr, "a/") {
+	// HasAuth() doesn't know about OAuth2 bearer token auth, so check
+	// HasOAuthToken() too -- otherwise OAuth-authenticated requests would
+	// be sent to the anonymous path and the token would never be used.
+	if (c.Authentication.HasAuth() == true || c.Authentication.HasOAuthToken()) && !strings.HasPrefix(urlStr, "a/") {
 		urlStr = "a/" + urlStr
 	}
 
@@ -285,7 +349,7 @@ func (c *Client) buildURLForRequest(urlStr string) (string, error) {
 // If v implements the io.Writer interface, the raw response body will be written to v,
 // without attempting to first decode it.
 func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
-	resp, err := c.client.Do(req)
+	resp, err := c.doWithRetry(req)
 	if err != nil {
 		return nil, err
 	}
@@ -302,11 +366,22 @@ func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
 
 	if v != nil {
 		defer resp.Body.Close()
+
+		reader := resp.Body
+		if resp.Header.Get("Content-Encoding") == "gzip" {
+			gzReader, err := gzip.NewReader(resp.Body)
+			if err != nil {
+				return response, err
+			}
+			defer gzReader.Close()
+			reader = gzReader
+		}
+
 		if w, ok := v.(io.Writer); ok {
-			io.Copy(w, resp.Body)
+			io.Copy(w, reader)
 		} else {
 			var body []byte
-			body, err = ioutil.ReadAll(resp.Body)
+			body, err = ioutil.ReadAll(reader)
 			if err != nil {
 				// even though there was an error, we still return the response
 				// in case the caller wants to inspect it further
@@ -320,7 +395,19 @@ func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
 	return response, err
 }
 
-func (c *Client) addAuthentication(req *http.Request) error {
+func (c *Client) addAuthentication(ctx context.Context, req *http.Request) error {
+	// Apply OAuth2 bearer token authentication. If a TokenSource was
+	// configured, this pulls a fresh (possibly refreshed) token on every
+	// request.
+	if c.Authentication.HasOAuthToken() {
+		token, err := c.Authentication.oauthBearerToken()
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	}
+
 	// Apply HTTP Basic Authentication
 	if c.Authentication.HasBasicAuth() {
 		req.SetBasicAuth(c.Authentication.name, c.Authentication.secret)
@@ -350,11 +437,12 @@ func (c *Client) addAuthentication(req *http.Request) error {
 		// WARNING: Don't use c.NewRequest here unless you like
 		// infinite recursion.
 		digestRequest, err := http.NewRequest(req.Method, uri, nil)
-		digestRequest.Header.Set("Accept", "*/*")
-		digestRequest.Header.Set("Content-Type", "application/json")
 		if err != nil {
 			return err
 		}
+		digestRequest = digestRequest.WithContext(ctx)
+		digestRequest.Header.Set("Accept", "*/*")
+		digestRequest.Header.Set("Content-Type", "application/json")
 
 		response, err := c.client.Do(digestRequest)
 		if err != nil {
@@ -416,7 +504,8 @@ func RemoveMagicPrefixLine(body []byte) []byte {
 
 // CheckResponse checks the API response for errors, and returns them if present.
 // A response is considered an error if it has a status code outside the 200 range.
-// API error responses are expected to have no response body.
+// Gerrit returns human-readable plain-text error messages in the body of
+// 4xx/5xx responses, so the returned *ErrorResponse captures it.
 //
 // Gerrit API docs: https://gerrit-review.googlesource.com/Documentation/rest-api.html#response-codes
 func CheckResponse(r *http.Response) error {
@@ -429,8 +518,7 @@ func CheckResponse(r *http.Response) error {
 	// 		API call to https://review.typo3.org/accounts/self failed: 403 Forbidden
 	// will be thrown.
 
-	err := fmt.Errorf("API call to %s failed: %s", r.Request.URL.String(), r.Status)
-	return err
+	return &ErrorResponse{Response: r, RawBody: readErrorBody(r)}
 }
 
 // addOptions adds the parameters in opt as URL query parameters to s.
@@ -0,0 +1,190 @@
+package gerrit
+
+import (
+	"bufio"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// NewClientFromGitConfig returns a new Gerrit API client authenticated the
+// same way git-codereview authenticates against *.googlesource.com Gerrit
+// instances: by locating the user's .gitcookies file (honoring
+// `git config http.cookiefile` if set, otherwise the default
+// ~/.gitcookies location), matching a cookie whose domain covers the host
+// of endpoint, and using its "o=<value>" value as cookie auth. If no
+// matching gitcookie is found, it falls back to ~/.netrc for a
+// username/password pair to configure basic auth.
+//
+// This lets callers instantiate a client against a googlesource.com Gerrit
+// instance without embedding credentials in the endpoint URL.
+func NewClientFromGitConfig(endpoint string, httpClient *http.Client) (*Client, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := NewClient(endpoint, httpClient)
+	if err != nil {
+		return c, err
+	}
+
+	if name, secret, ok := gitCookieAuth(u.Hostname()); ok {
+		c.Authentication.SetCookieAuth(name, secret)
+		return c, nil
+	}
+
+	if name, secret, ok := netrcAuth(u.Hostname()); ok {
+		c.Authentication.SetBasicAuth(name, secret)
+		return c, nil
+	}
+
+	return c, nil
+}
+
+// gitCookieAuth locates the gitcookies file (via `git config
+// http.cookiefile`, falling back to ~/.gitcookies) and returns the name and
+// value of the "o=<value>" cookie whose domain matches host, if any.
+func gitCookieAuth(host string) (name, secret string, ok bool) {
+	path := gitCookieFilePath()
+	if path == "" {
+		return "", "", false
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return "", "", false
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		domain, cookieName, cookieValue, ok := parseGitCookieLine(line)
+		if !ok {
+			continue
+		}
+		if !cookieDomainMatches(domain, host) {
+			continue
+		}
+		if cookieName == "o" {
+			// googlesource.com Gerrit expects the cookie sent back
+			// literally as "o", with its full "user=secret" value intact
+			// (Cookie: o=git-user.example.com=secret) -- do not split it.
+			return cookieName, cookieValue, true
+		}
+	}
+
+	return "", "", false
+}
+
+// gitCookieFilePath returns the path configured via `git config
+// http.cookiefile`, or ~/.gitcookies if that's unset or git isn't
+// available.
+func gitCookieFilePath() string {
+	out, err := exec.Command("git", "config", "http.cookiefile").Output()
+	if err == nil {
+		if path := strings.TrimSpace(string(out)); path != "" {
+			return path
+		}
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".gitcookies")
+}
+
+// parseGitCookieLine parses a single line of a Netscape-format cookie jar,
+// as produced by git-cookie-updater.sh. Blank lines and comments (other
+// than the "#HttpOnly_" domain prefix) are skipped.
+func parseGitCookieLine(line string) (domain, name, value string, ok bool) {
+	if strings.HasPrefix(line, "#HttpOnly_") {
+		line = strings.TrimPrefix(line, "#HttpOnly_")
+	} else if strings.HasPrefix(line, "#") || strings.TrimSpace(line) == "" {
+		return "", "", "", false
+	}
+
+	fields := strings.Split(line, "\t")
+	if len(fields) != 7 {
+		return "", "", "", false
+	}
+
+	return fields[0], fields[5], fields[6], true
+}
+
+// cookieDomainMatches reports whether a Netscape cookie jar domain entry
+// covers host. A leading dot on domain is ignored: domain matches itself and
+// any subdomain of it, with or without the dot.
+func cookieDomainMatches(domain, host string) bool {
+	domain = strings.TrimPrefix(domain, ".")
+	host = strings.TrimPrefix(host, ".")
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+// netrcAuth looks up a machine entry for host in ~/.netrc and returns its
+// login/password pair, if present.
+func netrcAuth(host string) (name, secret string, ok bool) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", "", false
+	}
+
+	f, err := os.Open(filepath.Join(home, ".netrc"))
+	if err != nil {
+		return "", "", false
+	}
+	defer f.Close()
+
+	var machine, login, password string
+	var inMachine bool
+
+	fields := strings.Fields(readAll(f))
+	for i := 0; i < len(fields); i++ {
+		switch fields[i] {
+		case "machine":
+			if inMachine && machine == host && login != "" {
+				return login, password, true
+			}
+			i++
+			if i >= len(fields) {
+				break
+			}
+			machine = fields[i]
+			inMachine = true
+			login, password = "", ""
+		case "login":
+			i++
+			if i < len(fields) {
+				login = fields[i]
+			}
+		case "password":
+			i++
+			if i < len(fields) {
+				password = fields[i]
+			}
+		}
+	}
+
+	if inMachine && machine == host && login != "" {
+		return login, password, true
+	}
+	return "", "", false
+}
+
+// readAll reads the remainder of f into a string. .netrc files are small
+// (a handful of machine entries), so slurping the whole file keeps the
+// tokenizer in netrcAuth simple.
+func readAll(f *os.File) string {
+	var sb strings.Builder
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		sb.WriteString(scanner.Text())
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
@@ -0,0 +1,165 @@
+package gerrit
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseGitCookieLine(t *testing.T) {
+	tests := []struct {
+		name       string
+		line       string
+		wantDomain string
+		wantName   string
+		wantValue  string
+		wantOK     bool
+	}{
+		{
+			name:       "valid o cookie",
+			line:       ".googlesource.com\tTRUE\t/\tTRUE\t2147483647\to\tgit-user.example.com=1/abc",
+			wantDomain: ".googlesource.com",
+			wantName:   "o",
+			wantValue:  "git-user.example.com=1/abc",
+			wantOK:     true,
+		},
+		{
+			name:       "HttpOnly prefixed domain",
+			line:       "#HttpOnly_.googlesource.com\tTRUE\t/\tTRUE\t2147483647\to\tgit-user.example.com=secret",
+			wantDomain: ".googlesource.com",
+			wantName:   "o",
+			wantValue:  "git-user.example.com=secret",
+			wantOK:     true,
+		},
+		{
+			name:   "comment line",
+			line:   "# Netscape HTTP Cookie File",
+			wantOK: false,
+		},
+		{
+			name:   "blank line",
+			line:   "",
+			wantOK: false,
+		},
+		{
+			name:   "too few fields",
+			line:   ".googlesource.com\tTRUE\t/\tTRUE\t2147483647\to",
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			domain, name, value, ok := parseGitCookieLine(tt.line)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if domain != tt.wantDomain || name != tt.wantName || value != tt.wantValue {
+				t.Errorf("got (%q, %q, %q), want (%q, %q, %q)",
+					domain, name, value, tt.wantDomain, tt.wantName, tt.wantValue)
+			}
+		})
+	}
+}
+
+func TestCookieDomainMatches(t *testing.T) {
+	tests := []struct {
+		domain, host string
+		want         bool
+	}{
+		{".googlesource.com", "go.googlesource.com", true},
+		{".googlesource.com", "googlesource.com", true},
+		{"googlesource.com", "go.googlesource.com", true},
+		{"googlesource.com", "googlesource.com", true},
+		{".googlesource.com", "evil-googlesource.com", false},
+		{"review.example.com", "other.example.com", false},
+	}
+
+	for _, tt := range tests {
+		if got := cookieDomainMatches(tt.domain, tt.host); got != tt.want {
+			t.Errorf("cookieDomainMatches(%q, %q) = %v, want %v", tt.domain, tt.host, got, tt.want)
+		}
+	}
+}
+
+// withHome points $HOME at a fresh temp directory for the duration of the
+// test and restores it afterward.
+func withHome(t *testing.T) string {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "gerrit-gitconfig-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	old, hadOld := os.LookupEnv("HOME")
+	if err := os.Setenv("HOME", dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if hadOld {
+			os.Setenv("HOME", old)
+		} else {
+			os.Unsetenv("HOME")
+		}
+	})
+
+	return dir
+}
+
+func TestGitCookieAuth(t *testing.T) {
+	home := withHome(t)
+
+	cookies := ".googlesource.com\tTRUE\t/\tTRUE\t2147483647\to\tgit-user.example.com=1/abc-secret\n" +
+		"other.example.com\tTRUE\t/\tTRUE\t2147483647\to\tgit-other.example.com=different\n"
+	if err := ioutil.WriteFile(filepath.Join(home, ".gitcookies"), []byte(cookies), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	name, secret, ok := gitCookieAuth("go.googlesource.com")
+	if !ok {
+		t.Fatal("gitCookieAuth() ok = false, want true")
+	}
+	if name != "o" {
+		t.Errorf("name = %q, want %q", name, "o")
+	}
+	if secret != "git-user.example.com=1/abc-secret" {
+		t.Errorf("secret = %q, want the full, unsplit o= value", secret)
+	}
+
+	if _, _, ok := gitCookieAuth("no-such-host.example.com"); ok {
+		t.Error("gitCookieAuth() for an unmatched host returned ok = true")
+	}
+}
+
+func TestNetrcAuth(t *testing.T) {
+	home := withHome(t)
+
+	netrc := "machine review.example.com login alice password s3cr3t\n" +
+		"machine other.example.com login bob password hunter2\n"
+	if err := ioutil.WriteFile(filepath.Join(home, ".netrc"), []byte(netrc), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	name, secret, ok := netrcAuth("review.example.com")
+	if !ok {
+		t.Fatal("netrcAuth() ok = false, want true")
+	}
+	if name != "alice" || secret != "s3cr3t" {
+		t.Errorf("got (%q, %q), want (%q, %q)", name, secret, "alice", "s3cr3t")
+	}
+
+	name, secret, ok = netrcAuth("other.example.com")
+	if !ok || name != "bob" || secret != "hunter2" {
+		t.Errorf("got (%q, %q, %v), want (%q, %q, true)", name, secret, ok, "bob", "hunter2")
+	}
+
+	if _, _, ok := netrcAuth("no-such-host.example.com"); ok {
+		t.Error("netrcAuth() for an unmatched host returned ok = true")
+	}
+}
@@ -0,0 +1,72 @@
+package gerrit
+
+import (
+	"net/http"
+
+	"golang.org/x/oauth2"
+)
+
+// SetOAuthToken sets a fixed OAuth2 bearer token to use for authentication.
+//
+// This is the mechanism used by googlesource.com-hosted Gerrit instances,
+// which accept OAuth2 access tokens carrying the
+// https://www.googleapis.com/auth/gerritcodereview scope.
+func (s *AuthenticationService) SetOAuthToken(token string) {
+	s.oauthToken = token
+	s.tokenSource = nil
+
+	s.basicAuth = false
+	s.cookieAuth = false
+	s.digestAuth = false
+	s.oauthAuth = true
+}
+
+// SetTokenSource configures the AuthenticationService to authenticate with
+// an oauth2.TokenSource, pulling a fresh token from it on every request.
+// Use this instead of SetOAuthToken when the token may expire, e.g. when
+// authenticating with a golang.org/x/oauth2/google credential.
+func (s *AuthenticationService) SetTokenSource(ts oauth2.TokenSource) {
+	s.tokenSource = ts
+	s.oauthToken = ""
+
+	s.basicAuth = false
+	s.cookieAuth = false
+	s.digestAuth = false
+	s.oauthAuth = true
+}
+
+// HasOAuthToken returns true if we have OAuth2 bearer token auth configured,
+// either as a fixed token or a TokenSource.
+func (s *AuthenticationService) HasOAuthToken() bool {
+	return s.oauthAuth
+}
+
+// oauthBearerToken returns the bearer token to send with the current
+// request, pulling a fresh token from the configured TokenSource if one was
+// provided.
+func (s *AuthenticationService) oauthBearerToken() (string, error) {
+	if s.tokenSource != nil {
+		token, err := s.tokenSource.Token()
+		if err != nil {
+			return "", err
+		}
+		return token.AccessToken, nil
+	}
+	return s.oauthToken, nil
+}
+
+// NewClientWithTokenSource returns a new Gerrit API client authenticated
+// against a googlesource.com-hosted (or other OAuth2-fronted) Gerrit
+// instance using the provided oauth2.TokenSource. This is the recommended
+// way to talk to Chromium- and Go-project Gerrit instances, which accept
+// the https://www.googleapis.com/auth/gerritcodereview scope, without
+// resorting to cookie or digest auth.
+func NewClientWithTokenSource(endpoint string, httpClient *http.Client, ts oauth2.TokenSource) (*Client, error) {
+	c, err := NewClient(endpoint, httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Authentication.SetTokenSource(ts)
+	return c, nil
+}
@@ -0,0 +1,141 @@
+package gerrit
+
+import (
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures how Client.Do retries a request that failed with a
+// transient error. The zero value disables retrying (a single attempt is
+// made), matching the client's historical behavior.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first.
+	// Values less than 2 disable retrying.
+	MaxAttempts int
+
+	// InitialBackoff is the base delay before the first retry. Subsequent
+	// retries back off exponentially from this value, plus jitter.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the computed backoff delay.
+	MaxBackoff time.Duration
+
+	// ShouldRetry decides whether a given response/error pair from an
+	// attempt is worth retrying. If nil, DefaultShouldRetry is used.
+	ShouldRetry func(*http.Response, error) bool
+}
+
+// DefaultShouldRetry is the ShouldRetry policy used when RetryPolicy.ShouldRetry
+// is nil. It retries on network errors (err != nil), 5xx responses, and 429
+// (Too Many Requests) responses.
+func DefaultShouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	if resp == nil {
+		return false
+	}
+	return resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+}
+
+// shouldRetry returns the configured ShouldRetry policy, falling back to
+// DefaultShouldRetry.
+func (p RetryPolicy) shouldRetry(resp *http.Response, err error) bool {
+	if p.ShouldRetry != nil {
+		return p.ShouldRetry(resp, err)
+	}
+	return DefaultShouldRetry(resp, err)
+}
+
+// backoff computes the delay before the given retry attempt (1-indexed: the
+// delay before the second overall attempt), honoring resp's Retry-After
+// header if present, else exponential backoff from InitialBackoff with
+// jitter, capped at MaxBackoff.
+func (p RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if d, ok := retryAfterDelay(resp); ok {
+			return d
+		}
+	}
+
+	initial := p.InitialBackoff
+	if initial <= 0 {
+		initial = 100 * time.Millisecond
+	}
+
+	delay := initial << uint(attempt-1)
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		delay = p.MaxBackoff
+	}
+
+	// Full jitter: a random delay in [0, delay).
+	if delay > 0 {
+		delay = time.Duration(rand.Int63n(int64(delay)))
+	}
+	return delay
+}
+
+// doWithRetry issues req, retrying according to c.Retry when the response
+// or error is retryable. req's body (if any) must be resendable via
+// req.GetBody, which http.NewRequest populates automatically for the
+// *bytes.Buffer bodies NewRequest constructs. Retries back off between
+// attempts and bail out immediately if req's context is cancelled.
+func (c *Client) doWithRetry(req *http.Request) (*http.Response, error) {
+	attempts := c.Retry.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	var resp *http.Response
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if attempt > 1 && req.GetBody != nil {
+			body, berr := req.GetBody()
+			if berr != nil {
+				return nil, berr
+			}
+			req.Body = body
+		}
+
+		resp, err = c.client.Do(req)
+		if attempt == attempts || !c.Retry.shouldRetry(resp, err) {
+			break
+		}
+
+		delay := c.Retry.backoff(attempt, resp)
+		if resp != nil {
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		}
+	}
+
+	return resp, err
+}
+
+// retryAfterDelay parses resp's Retry-After header, which Gerrit (and
+// well-behaved proxies in front of it) sets on 429 responses. It supports
+// only the delay-seconds form; the HTTP-date form is uncommon for Gerrit
+// and is ignored.
+func retryAfterDelay(resp *http.Response) (time.Duration, bool) {
+	v := resp.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	seconds, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, false
+	}
+	return time.Duration(seconds) * time.Second, true
+}
@@ -0,0 +1,98 @@
+package gerrit
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestDefaultShouldRetry(t *testing.T) {
+	tests := []struct {
+		name string
+		resp *http.Response
+		err  error
+		want bool
+	}{
+		{"network error", nil, errors.New("boom"), true},
+		{"5xx", &http.Response{StatusCode: 503}, nil, true},
+		{"429", &http.Response{StatusCode: 429}, nil, true},
+		{"200", &http.Response{StatusCode: 200}, nil, false},
+		{"404", &http.Response{StatusCode: 404}, nil, false},
+		{"nil response, nil error", nil, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DefaultShouldRetry(tt.resp, tt.err); got != tt.want {
+				t.Errorf("DefaultShouldRetry() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyShouldRetryUsesCustomPolicy(t *testing.T) {
+	p := RetryPolicy{
+		ShouldRetry: func(resp *http.Response, err error) bool {
+			return resp != nil && resp.StatusCode == http.StatusTeapot
+		},
+	}
+
+	if !p.shouldRetry(&http.Response{StatusCode: http.StatusTeapot}, nil) {
+		t.Error("shouldRetry() = false for the custom policy's retryable case")
+	}
+	if p.shouldRetry(&http.Response{StatusCode: 503}, nil) {
+		t.Error("shouldRetry() = true for a case the custom policy doesn't opt into")
+	}
+}
+
+func TestRetryAfterDelay(t *testing.T) {
+	tests := []struct {
+		name      string
+		header    string
+		wantDelay time.Duration
+		wantOK    bool
+	}{
+		{"absent", "", 0, false},
+		{"seconds", "5", 5 * time.Second, true},
+		{"not a number", "Wed, 21 Oct 2015 07:28:00 GMT", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if tt.header != "" {
+				resp.Header.Set("Retry-After", tt.header)
+			}
+
+			delay, ok := retryAfterDelay(resp)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && delay != tt.wantDelay {
+				t.Errorf("delay = %v, want %v", delay, tt.wantDelay)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyBackoffHonorsRetryAfter(t *testing.T) {
+	p := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: time.Minute}
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"2"}}}
+
+	if got := p.backoff(1, resp); got != 2*time.Second {
+		t.Errorf("backoff() = %v, want %v (the Retry-After value)", got, 2*time.Second)
+	}
+}
+
+func TestRetryPolicyBackoffCapsAtMaxBackoff(t *testing.T) {
+	p := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 2 * time.Second}
+
+	// Attempt 10 would be 2^9 seconds of uncapped exponential backoff; the
+	// jittered result must never exceed MaxBackoff.
+	for attempt := 1; attempt <= 10; attempt++ {
+		if got := p.backoff(attempt, nil); got > p.MaxBackoff {
+			t.Fatalf("backoff(%d) = %v, want <= MaxBackoff (%v)", attempt, got, p.MaxBackoff)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+// Package rpc defines a typed interface to the Gerrit Changes, Accounts and
+// Projects APIs, mirroring the major methods of gerrit.ChangesService,
+// gerrit.AccountsService and gerrit.ProjectsService.
+//
+// NewRESTClient wraps an existing *gerrit.Client and satisfies GerritClient
+// by translating calls to the JSON REST API. This is, deliberately, the
+// only transport this package ships: a gRPC-backed GerritClient needs
+// real protoc-generated message types satisfying proto.Message, which this
+// package cannot produce by hand (an earlier revision tried and shipped
+// types that would panic on the first Invoke). gerrit.proto documents the
+// message shapes a generated implementation would use; wiring one up,
+// plus the transport-selecting factory the original request asked for, is
+// left as follow-up work for whoever has a protoc toolchain to run.
+package rpc
@@ -0,0 +1,60 @@
+package rpc
+
+// The types below mirror the messages defined in gerrit.proto. They are
+// hand-written rather than protoc-generated: there is no generated,
+// proto.Message-satisfying gRPC transport yet (see gerrit.proto and
+// client.go), so these are plain structs used only by the REST-backed
+// GerritClient for now.
+
+// ChangeInfo is the typed counterpart of gerrit.ChangeInfo, trimmed to the
+// fields callers most commonly need.
+type ChangeInfo struct {
+	Id       string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Project  string `protobuf:"bytes,2,opt,name=project,proto3" json:"project,omitempty"`
+	Branch   string `protobuf:"bytes,3,opt,name=branch,proto3" json:"branch,omitempty"`
+	ChangeId string `protobuf:"bytes,4,opt,name=change_id,json=changeId,proto3" json:"change_id,omitempty"`
+	Subject  string `protobuf:"bytes,5,opt,name=subject,proto3" json:"subject,omitempty"`
+	Status   string `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Number   int32  `protobuf:"varint,7,opt,name=number,proto3" json:"number,omitempty"`
+}
+
+// GetChangeRequest is the request message for Gerrit.GetChange.
+type GetChangeRequest struct {
+	ChangeId string `protobuf:"bytes,1,opt,name=change_id,json=changeId,proto3" json:"change_id,omitempty"`
+}
+
+// ListChangesRequest is the request message for Gerrit.ListChanges.
+type ListChangesRequest struct {
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+}
+
+// ListChangesResponse is the response message for Gerrit.ListChanges.
+type ListChangesResponse struct {
+	Changes []*ChangeInfo `protobuf:"bytes,1,rep,name=changes,proto3" json:"changes,omitempty"`
+}
+
+// AccountInfo is the typed counterpart of gerrit.AccountInfo.
+type AccountInfo struct {
+	AccountId int32  `protobuf:"varint,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Name      string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Email     string `protobuf:"bytes,3,opt,name=email,proto3" json:"email,omitempty"`
+	Username  string `protobuf:"bytes,4,opt,name=username,proto3" json:"username,omitempty"`
+}
+
+// GetAccountRequest is the request message for Gerrit.GetAccount.
+type GetAccountRequest struct {
+	AccountId string `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+}
+
+// ProjectInfo is the typed counterpart of gerrit.ProjectInfo.
+type ProjectInfo struct {
+	Name        string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Parent      string `protobuf:"bytes,2,opt,name=parent,proto3" json:"parent,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	State       string `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
+}
+
+// GetProjectRequest is the request message for Gerrit.GetProject.
+type GetProjectRequest struct {
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
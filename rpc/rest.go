@@ -0,0 +1,74 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/banksean/go-gerrit"
+)
+
+// restClient implements GerritClient on top of an existing REST-backed
+// *gerrit.Client.
+type restClient struct {
+	c *gerrit.Client
+}
+
+func (r *restClient) GetChange(ctx context.Context, changeID string) (*ChangeInfo, error) {
+	info, _, err := r.c.Changes.GetChangeContext(ctx, changeID, nil)
+	if err != nil {
+		return nil, err
+	}
+	return changeInfoFromREST(info), nil
+}
+
+func (r *restClient) ListChanges(ctx context.Context, query string) ([]*ChangeInfo, error) {
+	infos, _, err := r.c.Changes.ListChangesContext(ctx, &gerrit.QueryChangeOptions{
+		QueryOptions: gerrit.QueryOptions{Query: []string{query}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]*ChangeInfo, 0, len(*infos))
+	for _, info := range *infos {
+		changes = append(changes, changeInfoFromREST(&info))
+	}
+	return changes, nil
+}
+
+func (r *restClient) GetAccount(ctx context.Context, accountID string) (*AccountInfo, error) {
+	info, _, err := r.c.Accounts.GetAccountContext(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	return &AccountInfo{
+		AccountId: int32(info.AccountID),
+		Name:      info.Name,
+		Email:     info.Email,
+		Username:  info.Username,
+	}, nil
+}
+
+func (r *restClient) GetProject(ctx context.Context, name string) (*ProjectInfo, error) {
+	info, _, err := r.c.Projects.GetProjectContext(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return &ProjectInfo{
+		Name:        info.Name,
+		Parent:      info.Parent,
+		Description: info.Description,
+		State:       info.State,
+	}, nil
+}
+
+func changeInfoFromREST(info *gerrit.ChangeInfo) *ChangeInfo {
+	return &ChangeInfo{
+		Id:       info.ID,
+		Project:  info.Project,
+		Branch:   info.Branch,
+		ChangeId: info.ChangeID,
+		Subject:  info.Subject,
+		Status:   info.Status,
+		Number:   int32(info.Number),
+	}
+}